@@ -0,0 +1,166 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package v1alpha1 contains the API definitions for the tanzu CLI client
+// configuration file.
+package v1alpha1
+
+import "time"
+
+// ContextType is the type of the context (control plane).
+type ContextType string
+
+const (
+	// CtxTypeTMC is a Tanzu Mission Control context.
+	CtxTypeTMC ContextType = "tmc"
+
+	// CtxTypeK8s is a Kubernetes context.
+	CtxTypeK8s ContextType = "k8s"
+)
+
+// ClientConfig is the schema for the tanzu CLI client configuration file.
+type ClientConfig struct {
+	// KnownServers contains deprecated server entries, kept for backwards
+	// compatibility with older CLI plugins. It is not filled in from
+	// KnownContexts; the config package synthesizes server entries from
+	// KnownContexts when they are read.
+	KnownServers []*Server `json:"servers,omitempty" yaml:"servers,omitempty"`
+
+	// CurrentServer is deprecated in favor of CurrentContext.
+	CurrentServer string `json:"current,omitempty" yaml:"current,omitempty"`
+
+	// KnownContexts contains all the contexts that the CLI knows about.
+	KnownContexts []*Context `json:"contexts,omitempty" yaml:"contexts,omitempty"`
+
+	// CurrentContext contains the current context for each context type.
+	CurrentContext map[ContextType]string `json:"currentContext,omitempty" yaml:"currentContext,omitempty"`
+
+	// ContextGroups contains the named groups (workspaces) that contexts can
+	// be scoped to. A context belongs to a group via its ContextGroup field.
+	ContextGroups []*ContextGroup `json:"contextGroups,omitempty" yaml:"contextGroups,omitempty"`
+
+	// CurrentContextGroup is the name of the active context group, if any.
+	// When set, GetCurrentContext resolves within this group before falling
+	// back to the global CurrentContext map.
+	CurrentContextGroup string `json:"currentContextGroup,omitempty" yaml:"currentContextGroup,omitempty"`
+
+	// ContextHistory holds, per context type, the bounded ring buffer of
+	// prior current context names, oldest first. It backs PushContext,
+	// PopContext and PeekPreviousContext.
+	ContextHistory map[ContextType][]string `json:"contextHistory,omitempty" yaml:"contextHistory,omitempty"`
+}
+
+// ContextGroup bundles a set of contexts (and, through them, their cluster
+// references) under a single named scope, so that a context name only needs
+// to be unique within its group. It holds no repository or manifest
+// references.
+type ContextGroup struct {
+	// Name of the context group.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// CurrentContext contains the current context for each context type,
+	// scoped to this group.
+	CurrentContext map[ContextType]string `json:"currentContext,omitempty" yaml:"currentContext,omitempty"`
+
+	// ContextHistory holds, per context type, this group's own bounded ring
+	// buffer of prior current context names, oldest first. It backs
+	// PushContext/PopContext/PeekPreviousContext while this group is active,
+	// kept separate from other groups' (and the global) history so that
+	// same-named contexts in different groups don't collide.
+	ContextHistory map[ContextType][]string `json:"contextHistory,omitempty" yaml:"contextHistory,omitempty"`
+}
+
+// Context is a set of parameters that identifies a control plane.
+type Context struct {
+	// Name of the context.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Type of the context.
+	Type ContextType `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// GlobalOpts contains the options for a global control plane, e.g. TMC.
+	GlobalOpts *GlobalServer `json:"globalOpts,omitempty" yaml:"globalOpts,omitempty"`
+
+	// ClusterOpts contains the options for a Kubernetes cluster context.
+	ClusterOpts *ClusterServer `json:"clusterOpts,omitempty" yaml:"clusterOpts,omitempty"`
+
+	// ContextGroup is the name of the context group this context belongs to,
+	// if any. A context's Name only needs to be unique within its group.
+	ContextGroup string `json:"contextGroup,omitempty" yaml:"contextGroup,omitempty"`
+
+	// BootstrapOpts is set when the context represents a short-lived
+	// bootstrap-token credential rather than a durable context.
+	BootstrapOpts *BootstrapTokenServer `json:"bootstrapOpts,omitempty" yaml:"bootstrapOpts,omitempty"`
+
+	// LastVerifyResult holds the outcome of the most recent passing
+	// ContextVerifier check run against this context, if any. Failed checks
+	// are not recorded.
+	LastVerifyResult *VerificationResult `json:"lastVerifyResult,omitempty" yaml:"lastVerifyResult,omitempty"`
+}
+
+// VerificationResult records the outcome of a ContextVerifier check.
+type VerificationResult struct {
+	// OK is true if the context passed verification.
+	OK bool `json:"ok,omitempty" yaml:"ok,omitempty"`
+
+	// Evidence is verifier-specific supporting data, e.g. a certificate
+	// chain or an introspection response.
+	Evidence []byte `json:"evidence,omitempty" yaml:"evidence,omitempty"`
+
+	// Timestamp is when the check was performed.
+	Timestamp time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+}
+
+// BootstrapTokenServer holds a kubeadm-style bootstrap token of the form
+// "<id>.<secret>" and its expiry.
+type BootstrapTokenServer struct {
+	// ID is the public, non-secret identifier of the token.
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+
+	// Secret is the private part of the token.
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty" encrypt:"true"`
+
+	// TTL is the duration the token is valid for from the time it was
+	// issued or last refreshed.
+	TTL time.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+
+	// Expires is the point in time at which the token is no longer valid.
+	Expires time.Time `json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+// Server is the deprecated representation of a context, kept for
+// backwards-compatible reads/writes of the configuration file.
+type Server struct {
+	// Name of the server.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Type of the server.
+	Type ContextType `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// GlobalOpts contains the options if the server is a global control plane.
+	GlobalOpts *GlobalServer `json:"globalOpts,omitempty" yaml:"globalOpts,omitempty"`
+
+	// ClusterOpts contains the options if the server is a Kubernetes cluster.
+	ClusterOpts *ClusterServer `json:"clusterOpts,omitempty" yaml:"clusterOpts,omitempty"`
+}
+
+// ClusterServer contains the parameters for a Kubernetes cluster context.
+type ClusterServer struct {
+	// Endpoint for the login.
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty" encrypt:"true"`
+
+	// Path to the kubeconfig.
+	Path string `json:"path,omitempty" yaml:"path,omitempty" encrypt:"true"`
+
+	// Context is the kubeconfig context to use.
+	Context string `json:"context,omitempty" yaml:"context,omitempty" encrypt:"true"`
+
+	// IsManagementCluster notes whether this context points at a management cluster.
+	IsManagementCluster bool `json:"isManagementCluster,omitempty" yaml:"isManagementCluster,omitempty"`
+}
+
+// GlobalServer contains the parameters for a global control plane context, e.g. TMC.
+type GlobalServer struct {
+	// Endpoint for the login.
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty" encrypt:"true"`
+}
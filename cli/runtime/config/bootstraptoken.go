@@ -0,0 +1,135 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+const (
+	bootstrapTokenIDLength     = 6
+	bootstrapTokenSecretLength = 16
+)
+
+// bootstrapTokenCharset matches the kubeadm bootstrap token charset: lowercase
+// letters and digits.
+var bootstrapTokenCharset = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// ErrContextExpired is returned (wrapped) by GetContext/GetCurrentContext
+// when the requested context is a bootstrap context whose TTL has elapsed
+// and that same call pruned it. Once pruned, the context is no longer
+// known, so later lookups return a not-found error instead.
+var ErrContextExpired = errors.New("context has expired")
+
+// AddBootstrapContext adds an ephemeral bootstrap-token context. ctx.Name
+// must be a token of the form "<id>.<secret>"; it is validated and used to
+// populate ctx.BootstrapOpts, whose Expires is set to ttl from now.
+func AddBootstrapContext(ctx *v1alpha1.Context, ttl time.Duration) error {
+	id, secret, err := parseBootstrapToken(ctx.Name)
+	if err != nil {
+		return err
+	}
+
+	ctx.BootstrapOpts = &v1alpha1.BootstrapTokenServer{
+		ID:      id,
+		Secret:  secret,
+		TTL:     ttl,
+		Expires: time.Now().Add(ttl),
+	}
+
+	return AddContext(ctx, false)
+}
+
+// RefreshBootstrapContext extends the named bootstrap context's TTL from
+// now.
+func RefreshBootstrapContext(name string, ttl time.Duration) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Name != name {
+			continue
+		}
+		if ctx.BootstrapOpts == nil {
+			return fmt.Errorf("context %q is not a bootstrap context", name)
+		}
+		ctx.BootstrapOpts.TTL = ttl
+		ctx.BootstrapOpts.Expires = time.Now().Add(ttl)
+		return StoreClientConfig(cfg)
+	}
+
+	return fmt.Errorf("could not find context %q", name)
+}
+
+// PruneExpiredContexts removes every bootstrap context whose TTL has
+// elapsed and returns the names of the contexts that were pruned. Any
+// current-context/current-server reference to a pruned context is cleared
+// in the same pass, so no dangling reference to it is left behind; later
+// lookups of a pruned context treat it as unknown rather than expired.
+func PruneExpiredContexts() ([]string, error) {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var pruned []string
+	remaining := cfg.KnownContexts[:0]
+	for _, ctx := range cfg.KnownContexts {
+		if isBootstrapContextExpired(ctx, now) {
+			pruned = append(pruned, ctx.Name)
+			clearCurrentContextReferences(cfg, ctx)
+			pruneContextHistoryEntry(historyMapFor(cfg, getContextGroup(cfg, ctx.ContextGroup)), ctx.Type, ctx.Name)
+			continue
+		}
+		remaining = append(remaining, ctx)
+	}
+	cfg.KnownContexts = remaining
+
+	if len(pruned) == 0 {
+		return nil, nil
+	}
+
+	return pruned, StoreClientConfig(cfg)
+}
+
+// isBootstrapContextExpired reports whether ctx is a bootstrap context whose
+// TTL has elapsed as of now.
+func isBootstrapContextExpired(ctx *v1alpha1.Context, now time.Time) bool {
+	return ctx.BootstrapOpts != nil && !ctx.BootstrapOpts.Expires.IsZero() && now.After(ctx.BootstrapOpts.Expires)
+}
+
+// parseBootstrapToken validates token is of the form "<id>.<secret>" with a
+// 6-character id and 16-character secret, both lowercase alphanumeric, and
+// returns the two parts.
+func parseBootstrapToken(token string) (id, secret string, err error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid bootstrap token %q: expected format \"<id>.<secret>\"", token)
+	}
+
+	id, secret = parts[0], parts[1]
+	if len(id) != bootstrapTokenIDLength || !bootstrapTokenCharset.MatchString(id) {
+		return "", "", fmt.Errorf("invalid bootstrap token id %q: must be %d lowercase alphanumeric characters", id, bootstrapTokenIDLength)
+	}
+	if len(secret) != bootstrapTokenSecretLength || !bootstrapTokenCharset.MatchString(secret) {
+		return "", "", fmt.Errorf("invalid bootstrap token secret: must be %d lowercase alphanumeric characters", bootstrapTokenSecretLength)
+	}
+
+	return id, secret, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config provides the client for reading and writing the tanzu CLI
+// configuration file.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// GetClientConfig retrieves the tanzu CLI config from the local directory.
+// If no config file exists yet, an empty ClientConfig is returned.
+func GetClientConfig() (*v1alpha1.ClientConfig, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &v1alpha1.ClientConfig{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	cfg := &v1alpha1.ClientConfig{}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, err
+	}
+
+	provider, err := activeKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+	if provider != nil {
+		// Best-effort: if the key is unavailable, leave the sealed fields
+		// as opaque ciphertext rather than failing the whole read, so that
+		// Name/Type based lookups (ContextExists, enumeration) still work
+		// without unlocking.
+		if key, err := provider.GetKey(); err == nil {
+			if err := decryptClientConfig(cfg, key); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// StoreClientConfig writes the tanzu CLI config to the local directory,
+// sealing any `encrypt:"true"` fields if a KeyProvider is active via
+// TANZU_CONFIG_ENCRYPTION. Callers are expected to hold the config lock via
+// AcquireTanzuConfigLock.
+func StoreClientConfig(cfg *v1alpha1.ClientConfig) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+
+	provider, err := activeKeyProvider()
+	if err != nil {
+		return err
+	}
+
+	toWrite := cfg
+	if provider != nil {
+		key, err := provider.GetKey()
+		if err != nil {
+			return fmt.Errorf("could not encrypt config: %w", err)
+		}
+
+		// Encrypt a deep copy so the caller's in-memory cfg stays plaintext.
+		toWrite, err = cloneClientConfig(cfg)
+		if err != nil {
+			return err
+		}
+		if err := encryptClientConfig(toWrite, key); err != nil {
+			return fmt.Errorf("could not encrypt config: %w", err)
+		}
+	}
+
+	b, err := yaml.Marshal(toWrite)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0644)
+}
+
+// cloneClientConfig returns a deep copy of cfg via a yaml round-trip.
+func cloneClientConfig(cfg *v1alpha1.ClientConfig) (*v1alpha1.ClientConfig, error) {
+	b, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := &v1alpha1.ClientConfig{}
+	if err := yaml.Unmarshal(b, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
@@ -0,0 +1,23 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"math/rand"
+	"time"
+)
+
+const letterBytes = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+var seededRand = rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+
+// randString returns a random alphanumeric string, used by tests to obtain a
+// throwaway LocalDirName so parallel test runs don't collide.
+func randString() string {
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = letterBytes[seededRand.Intn(len(letterBytes))]
+	}
+	return string(b)
+}
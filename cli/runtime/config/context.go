@@ -0,0 +1,350 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// GetContext retrieves the context identified by name. Expired bootstrap
+// contexts are swept opportunistically; if name refers to one, a wrapped
+// ErrContextExpired is returned instead of a generic not-found error.
+func GetContext(name string) (*v1alpha1.Context, error) {
+	pruned, err := PruneExpiredContexts()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pruned {
+		if p == name {
+			return nil, fmt.Errorf("context %q has expired: %w", name, ErrContextExpired)
+		}
+	}
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Name == name {
+			return ctx, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find context %q", name)
+}
+
+// ContextExists reports whether a context with the given name is known.
+func ContextExists(name string) (bool, error) {
+	_, err := GetContext(name)
+	if err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// AddContext adds a new context to the config. If current is true, it is
+// also set as the current context for its type. The context is added
+// ungrouped; use AddContextToGroup to scope it to a context group.
+func AddContext(ctx *v1alpha1.Context, current bool) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := addContextLocked(cfg, ctx, current); err != nil {
+		return err
+	}
+
+	return StoreClientConfig(cfg)
+}
+
+// AddContextToGroup adds a new context scoped to the named context group.
+// The context's Name only needs to be unique within that group. If current
+// is true, it is also set as the group's current context for its type.
+func AddContextToGroup(groupName string, ctx *v1alpha1.Context, current bool) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	group := getContextGroup(cfg, groupName)
+	if group == nil {
+		return fmt.Errorf("context group %q not found", groupName)
+	}
+
+	ctx.ContextGroup = groupName
+	if err := addContextLocked(cfg, ctx, false); err != nil {
+		return err
+	}
+
+	if current {
+		if group.CurrentContext == nil {
+			group.CurrentContext = make(map[v1alpha1.ContextType]string)
+		}
+		group.CurrentContext[ctx.Type] = ctx.Name
+	}
+
+	return StoreClientConfig(cfg)
+}
+
+// addContextLocked appends ctx to cfg, scoping the "already exists" check to
+// ctx.ContextGroup so that contexts in different groups may share a name.
+// Callers must hold the config lock.
+func addContextLocked(cfg *v1alpha1.ClientConfig, ctx *v1alpha1.Context, current bool) error {
+	for _, known := range cfg.KnownContexts {
+		if known.Name == ctx.Name && known.ContextGroup == ctx.ContextGroup {
+			return fmt.Errorf("context %q already exists", ctx.Name)
+		}
+	}
+
+	cfg.KnownContexts = append(cfg.KnownContexts, ctx)
+
+	if current {
+		if cfg.CurrentContext == nil {
+			cfg.CurrentContext = make(map[v1alpha1.ContextType]string)
+		}
+		cfg.CurrentContext[ctx.Type] = ctx.Name
+		if ctx.Type == v1alpha1.CtxTypeK8s {
+			cfg.CurrentServer = ctx.Name
+		}
+	}
+
+	return nil
+}
+
+// RemoveContext removes the context identified by name.
+func RemoveContext(name string) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	idx := -1
+	for i, ctx := range cfg.KnownContexts {
+		if ctx.Name == name {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("context %s not found", name)
+	}
+
+	removed := cfg.KnownContexts[idx]
+	ctxType := removed.Type
+	cfg.KnownContexts = append(cfg.KnownContexts[:idx], cfg.KnownContexts[idx+1:]...)
+
+	clearCurrentContextReferences(cfg, removed)
+	pruneContextHistoryEntry(historyMapFor(cfg, getContextGroup(cfg, removed.ContextGroup)), ctxType, name)
+
+	return StoreClientConfig(cfg)
+}
+
+// SetCurrentContext sets the context identified by name as the current
+// context for its type. If a context group is active, it resolves name
+// within that group first (so group-scoped contexts win over ungrouped
+// ones sharing the same name) and records it as the group's current
+// context; otherwise it falls back to the global, ungrouped lookup. If a
+// ContextVerifier is registered for the context's type, it must pass before
+// the switch is allowed.
+func SetCurrentContext(name string) error {
+	return setCurrentContext(name, false)
+}
+
+// SetCurrentContextSkipVerify behaves like SetCurrentContext but bypasses
+// any registered ContextVerifier, mirroring a CLI --skip-verify flag.
+func SetCurrentContextSkipVerify(name string) error {
+	return setCurrentContext(name, true)
+}
+
+func setCurrentContext(name string, skipVerify bool) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	group := getContextGroup(cfg, cfg.CurrentContextGroup)
+
+	var ctx *v1alpha1.Context
+	if group != nil {
+		for _, known := range cfg.KnownContexts {
+			if known.Name == name && known.ContextGroup == group.Name {
+				ctx = known
+				break
+			}
+		}
+	}
+	if ctx == nil {
+		for _, known := range cfg.KnownContexts {
+			if known.Name == name && known.ContextGroup == "" {
+				ctx = known
+				break
+			}
+		}
+	}
+	if ctx == nil {
+		return fmt.Errorf("could not find context %q", name)
+	}
+
+	if err := verifyContext(ctx, skipVerify); err != nil {
+		return err
+	}
+
+	var previous string
+	var scopeGroup *v1alpha1.ContextGroup
+	if ctx.ContextGroup != "" && group != nil {
+		scopeGroup = group
+		previous = group.CurrentContext[ctx.Type]
+		if group.CurrentContext == nil {
+			group.CurrentContext = make(map[v1alpha1.ContextType]string)
+		}
+		group.CurrentContext[ctx.Type] = ctx.Name
+	} else {
+		previous = cfg.CurrentContext[ctx.Type]
+		if cfg.CurrentContext == nil {
+			cfg.CurrentContext = make(map[v1alpha1.ContextType]string)
+		}
+		cfg.CurrentContext[ctx.Type] = ctx.Name
+	}
+	if previous != "" && previous != ctx.Name {
+		pushContextHistory(historyMapFor(cfg, scopeGroup), ctx.Type, previous)
+	}
+	if ctx.Type == v1alpha1.CtxTypeK8s {
+		cfg.CurrentServer = ctx.Name
+	}
+
+	return StoreClientConfig(cfg)
+}
+
+// GetCurrentContext returns the current context for the given context type.
+// If a context group is active, its scoped current context is preferred;
+// otherwise the global CurrentContext map is used. Expired bootstrap
+// contexts are swept opportunistically; if the current context for ctxType
+// just expired, a wrapped ErrContextExpired is returned. If a ContextVerifier
+// is registered for ctxType and the context's LastVerifyResult is missing,
+// failed, or older than VerificationStaleAfter, the context is still
+// returned but wrapped with ErrVerificationStale so callers can surface the
+// staleness instead of silently trusting it.
+func GetCurrentContext(ctxType v1alpha1.ContextType) (*v1alpha1.Context, error) {
+	// PruneExpiredContexts clears the current-context reference of every
+	// context it prunes, so the current name is read before pruning.
+	before, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	currentName := before.CurrentContext[ctxType]
+	if group := getContextGroup(before, before.CurrentContextGroup); group != nil && group.CurrentContext[ctxType] != "" {
+		currentName = group.CurrentContext[ctxType]
+	}
+
+	pruned, err := PruneExpiredContexts()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pruned {
+		if currentName != "" && p == currentName {
+			return nil, fmt.Errorf("context %q has expired: %w", currentName, ErrContextExpired)
+		}
+	}
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if group := getContextGroup(cfg, cfg.CurrentContextGroup); group != nil {
+		if name, ok := group.CurrentContext[ctxType]; ok && name != "" {
+			for _, ctx := range cfg.KnownContexts {
+				if ctx.Name == name && ctx.ContextGroup == group.Name {
+					return checkVerificationStaleness(ctx)
+				}
+			}
+			for _, p := range pruned {
+				if p == name {
+					return nil, fmt.Errorf("context %q has expired: %w", name, ErrContextExpired)
+				}
+			}
+			return nil, fmt.Errorf("could not find context %q", name)
+		}
+	}
+
+	name, ok := cfg.CurrentContext[ctxType]
+	if !ok || name == "" {
+		return nil, fmt.Errorf("no current context set for type %q", ctxType)
+	}
+
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Name == name {
+			return checkVerificationStaleness(ctx)
+		}
+	}
+
+	for _, p := range pruned {
+		if p == name {
+			return nil, fmt.Errorf("context %q has expired: %w", name, ErrContextExpired)
+		}
+	}
+	return nil, fmt.Errorf("could not find context %q", name)
+}
+
+// checkVerificationStaleness returns ctx, wrapping ErrVerificationStale if
+// ctxType has a registered ContextVerifier but ctx's LastVerifyResult is
+// missing, failed, or older than VerificationStaleAfter. ctx is always
+// returned alongside the error so callers that only care about staleness as
+// a warning can still use it.
+func checkVerificationStaleness(ctx *v1alpha1.Context) (*v1alpha1.Context, error) {
+	if verifierFor(ctx.Type) == nil {
+		return ctx, nil
+	}
+
+	result := ctx.LastVerifyResult
+	if result == nil || !result.OK || time.Since(result.Timestamp) > VerificationStaleAfter {
+		return ctx, fmt.Errorf("context %q: %w", ctx.Name, ErrVerificationStale)
+	}
+
+	return ctx, nil
+}
+
+// contextToServer mirrors a Context into the deprecated Server shape so that
+// older CLI plugins that only understand servers keep working.
+func contextToServer(ctx *v1alpha1.Context) *v1alpha1.Server {
+	return &v1alpha1.Server{
+		Name:        ctx.Name,
+		Type:        ctx.Type,
+		GlobalOpts:  ctx.GlobalOpts,
+		ClusterOpts: ctx.ClusterOpts,
+	}
+}
+
+// clearCurrentContextReferences removes every dangling pointer to ctx left
+// behind when it is removed or pruned: its group's (or the global) current
+// context entry for its type, and the legacy CurrentServer field.
+func clearCurrentContextReferences(cfg *v1alpha1.ClientConfig, ctx *v1alpha1.Context) {
+	if ctx.ContextGroup != "" {
+		if group := getContextGroup(cfg, ctx.ContextGroup); group != nil && group.CurrentContext[ctx.Type] == ctx.Name {
+			delete(group.CurrentContext, ctx.Type)
+		}
+	} else if cfg.CurrentContext[ctx.Type] == ctx.Name {
+		delete(cfg.CurrentContext, ctx.Type)
+	}
+	if cfg.CurrentServer == ctx.Name {
+		cfg.CurrentServer = ""
+	}
+}
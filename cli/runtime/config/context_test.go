@@ -4,8 +4,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -386,3 +388,399 @@ func TestGetCurrentContext(t *testing.T) {
 		})
 	}
 }
+
+func TestContextGroupScoping(t *testing.T) {
+	setup(t)
+	defer cleanup()
+
+	require.NoError(t, AddContextGroup("group-a"))
+	require.NoError(t, AddContextGroup("group-b"))
+
+	_, err := GetContextGroup("group-a")
+	require.NoError(t, err)
+	_, err = GetContextGroup("does-not-exist")
+	assert.EqualError(t, err, "context group \"does-not-exist\" not found")
+
+	groupACtx := &v1alpha1.Context{
+		Name: "test-mc",
+		Type: v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{
+			Endpoint: "group-a-endpoint",
+		},
+	}
+	groupBCtx := &v1alpha1.Context{
+		Name: "test-mc",
+		Type: v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{
+			Endpoint: "group-b-endpoint",
+		},
+	}
+
+	// Both groups can hold their own "test-mc" without colliding, even
+	// though a global "test-mc" already exists from setup().
+	require.NoError(t, AddContextToGroup("group-a", groupACtx, true))
+	require.NoError(t, AddContextToGroup("group-b", groupBCtx, true))
+
+	err = AddContextToGroup("group-a", &v1alpha1.Context{Name: "test-mc", Type: v1alpha1.CtxTypeK8s}, false)
+	assert.EqualError(t, err, "context \"test-mc\" already exists")
+
+	ctxs, err := ListContextsInGroup("group-a")
+	require.NoError(t, err)
+	require.Len(t, ctxs, 1)
+	assert.Equal(t, "group-a-endpoint", ctxs[0].ClusterOpts.Endpoint)
+
+	// Switching the active group changes what GetCurrentContext returns,
+	// without needing to rewrite the whole config file.
+	require.NoError(t, SetCurrentContextGroup("group-a"))
+	curr, err := GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "group-a-endpoint", curr.ClusterOpts.Endpoint)
+
+	require.NoError(t, SetCurrentContextGroup("group-b"))
+	curr, err = GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "group-b-endpoint", curr.ClusterOpts.Endpoint)
+
+	// The global (ungrouped) current context from setup() is untouched.
+	require.NoError(t, RemoveContextGroup("group-b"))
+	curr, err = GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "test-endpoint", curr.ClusterOpts.Endpoint)
+}
+
+func TestPushPopContext(t *testing.T) {
+	setup(t)
+	defer cleanup()
+
+	require.NoError(t, AddContext(&v1alpha1.Context{
+		Name: "test-mc2",
+		Type: v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{
+			Endpoint: "test-endpoint-2",
+		},
+	}, false))
+	require.NoError(t, AddContext(&v1alpha1.Context{
+		Name: "test-tmc2",
+		Type: v1alpha1.CtxTypeTMC,
+		GlobalOpts: &v1alpha1.GlobalServer{
+			Endpoint: "test-endpoint-2",
+		},
+	}, false))
+
+	// test-mc is already current for k8s (from setup); pushing test-mc2
+	// should record test-mc as the previous context.
+	require.NoError(t, PushContext("test-mc2"))
+	prev, err := PeekPreviousContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "test-mc", prev)
+
+	curr, err := GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "test-mc2", curr.Name)
+
+	require.NoError(t, PopContext(v1alpha1.CtxTypeK8s))
+	curr, err = GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "test-mc", curr.Name)
+
+	// Same push/pop flow for the tmc history, independent of k8s.
+	require.NoError(t, PushContext("test-tmc2"))
+	curr, err = GetCurrentContext(v1alpha1.CtxTypeTMC)
+	require.NoError(t, err)
+	assert.Equal(t, "test-tmc2", curr.Name)
+
+	require.NoError(t, PopContext(v1alpha1.CtxTypeTMC))
+	curr, err = GetCurrentContext(v1alpha1.CtxTypeTMC)
+	require.NoError(t, err)
+	assert.Equal(t, "test-tmc", curr.Name)
+
+	// Pop on an empty history returns an error.
+	err = PopContext(v1alpha1.CtxTypeTMC)
+	assert.EqualError(t, err, "no previous context to pop for type \"tmc\"")
+}
+
+func TestPushPopContextGroupScoped(t *testing.T) {
+	setup(t)
+	defer cleanup()
+
+	require.NoError(t, AddContextGroup("g1"))
+	require.NoError(t, AddContextToGroup("g1", &v1alpha1.Context{
+		Name:        "g1-a",
+		Type:        v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{Endpoint: "g1-a-endpoint"},
+	}, true))
+	require.NoError(t, AddContextToGroup("g1", &v1alpha1.Context{
+		Name:        "g1-b",
+		Type:        v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{Endpoint: "g1-b-endpoint"},
+	}, false))
+	require.NoError(t, SetCurrentContextGroup("g1"))
+
+	require.NoError(t, PushContext("g1-b"))
+	curr, err := GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "g1-b-endpoint", curr.ClusterOpts.Endpoint)
+
+	// Popping must restore within the active group, not the global
+	// CurrentContext map.
+	require.NoError(t, PopContext(v1alpha1.CtxTypeK8s))
+	curr, err = GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "g1-a-endpoint", curr.ClusterOpts.Endpoint)
+}
+
+func TestContextHistoryGroupScoping(t *testing.T) {
+	setup(t)
+	defer cleanup()
+
+	require.NoError(t, AddContextGroup("g1"))
+	require.NoError(t, AddContextGroup("g2"))
+
+	// g2's "shared" context is added first, so RemoveContext's name-only
+	// lookup below targets it rather than g1's.
+	require.NoError(t, AddContextToGroup("g2", &v1alpha1.Context{
+		Name:        "shared",
+		Type:        v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{Endpoint: "g2-shared"},
+	}, true))
+	require.NoError(t, AddContextToGroup("g2", &v1alpha1.Context{
+		Name:        "g2-other",
+		Type:        v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{Endpoint: "g2-other"},
+	}, false))
+	require.NoError(t, AddContextToGroup("g1", &v1alpha1.Context{
+		Name:        "shared",
+		Type:        v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{Endpoint: "g1-shared"},
+	}, true))
+	require.NoError(t, AddContextToGroup("g1", &v1alpha1.Context{
+		Name:        "g1-other",
+		Type:        v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{Endpoint: "g1-other"},
+	}, false))
+
+	// Each group pushes over its own "shared" context, landing "shared" in
+	// that group's own history bucket.
+	require.NoError(t, SetCurrentContextGroup("g2"))
+	require.NoError(t, PushContext("g2-other"))
+
+	require.NoError(t, SetCurrentContextGroup("g1"))
+	require.NoError(t, PushContext("g1-other"))
+
+	// Removing g2's "shared" context must only prune g2's own history
+	// bucket, leaving g1's still-live "shared" entry untouched.
+	require.NoError(t, RemoveContext("shared"))
+
+	prev, err := PeekPreviousContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "shared", prev)
+
+	require.NoError(t, PopContext(v1alpha1.CtxTypeK8s))
+	curr, err := GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "g1-shared", curr.ClusterOpts.Endpoint)
+}
+
+func TestContextHistoryRingBufferEviction(t *testing.T) {
+	setup(t)
+	defer cleanup()
+
+	ContextHistoryLimit = 3
+	defer func() { ContextHistoryLimit = 20 }()
+
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("test-mc-evict-%d", i)
+		require.NoError(t, AddContext(&v1alpha1.Context{
+			Name: name,
+			Type: v1alpha1.CtxTypeK8s,
+			ClusterOpts: &v1alpha1.ClusterServer{
+				Endpoint: name,
+			},
+		}, false))
+		require.NoError(t, PushContext(name))
+	}
+
+	cfg, err := GetClientConfig()
+	require.NoError(t, err)
+	require.Len(t, cfg.ContextHistory[v1alpha1.CtxTypeK8s], ContextHistoryLimit)
+	// The oldest entries (test-mc, test-mc-evict-0) should have been evicted.
+	assert.Equal(t, []string{"test-mc-evict-1", "test-mc-evict-2", "test-mc-evict-3"}, cfg.ContextHistory[v1alpha1.CtxTypeK8s])
+}
+
+func TestAddBootstrapContext(t *testing.T) {
+	setup(t)
+	defer cleanup()
+
+	tcs := []struct {
+		name   string
+		token  string
+		ttl    time.Duration
+		errStr string
+	}{
+		{
+			name:  "success",
+			token: "abc123.0123456789abcdef",
+			ttl:   time.Hour,
+		},
+		{
+			name:   "malformed no separator",
+			token:  "abc1230123456789abcdef",
+			errStr: "invalid bootstrap token \"abc1230123456789abcdef\": expected format \"<id>.<secret>\"",
+		},
+		{
+			name:   "malformed id length",
+			token:  "abc.0123456789abcdef",
+			errStr: "invalid bootstrap token id \"abc\": must be 6 lowercase alphanumeric characters",
+		},
+		{
+			name:   "malformed secret charset",
+			token:  "abc123.0123456789ABCDEF",
+			errStr: "invalid bootstrap token secret: must be 16 lowercase alphanumeric characters",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := &v1alpha1.Context{
+				Name: tc.token,
+				Type: v1alpha1.CtxTypeK8s,
+				ClusterOpts: &v1alpha1.ClusterServer{
+					Endpoint: "test-endpoint",
+				},
+			}
+
+			err := AddBootstrapContext(ctx, tc.ttl)
+			if tc.errStr == "" {
+				assert.NoError(t, err)
+				stored, err := GetContext(tc.token)
+				require.NoError(t, err)
+				assert.Equal(t, "abc123", stored.BootstrapOpts.ID)
+				assert.Equal(t, "0123456789abcdef", stored.BootstrapOpts.Secret)
+			} else {
+				assert.EqualError(t, err, tc.errStr)
+			}
+		})
+	}
+}
+
+func TestBootstrapContextExpiryAndRefresh(t *testing.T) {
+	setup(t)
+	defer cleanup()
+
+	token := "xyz789.fedcba9876543210"
+	require.NoError(t, AddBootstrapContext(&v1alpha1.Context{
+		Name: token,
+		Type: v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{
+			Endpoint: "test-endpoint",
+		},
+	}, -time.Second)) // already expired
+
+	_, err := GetContext(token)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrContextExpired))
+
+	ok, err := ContextExists(token)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	token2 := "abc999.fedcba9876543210"
+	require.NoError(t, AddBootstrapContext(&v1alpha1.Context{
+		Name: token2,
+		Type: v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{
+			Endpoint: "test-endpoint",
+		},
+	}, time.Hour))
+
+	require.NoError(t, RefreshBootstrapContext(token2, 2*time.Hour))
+	refreshed, err := GetContext(token2)
+	require.NoError(t, err)
+	assert.True(t, refreshed.BootstrapOpts.Expires.After(time.Now().Add(time.Hour)))
+
+	err = RefreshBootstrapContext("does-not-exist", time.Hour)
+	assert.EqualError(t, err, "could not find context \"does-not-exist\"")
+}
+
+// fakeVerifier is a ContextVerifier double used to test SetCurrentContext's
+// verification hook without dialing a real endpoint.
+type fakeVerifier struct {
+	ok       bool
+	evidence []byte
+	err      error
+}
+
+func (f *fakeVerifier) Verify(*v1alpha1.Context) (bool, []byte, error) {
+	return f.ok, f.evidence, f.err
+}
+
+func TestSetCurrentContextVerification(t *testing.T) {
+	setup(t)
+	defer cleanup()
+	defer delete(verifiers, v1alpha1.CtxTypeK8s)
+
+	RegisterVerifier(v1alpha1.CtxTypeK8s, &fakeVerifier{ok: false})
+
+	// A failing verifier blocks the switch.
+	err := SetCurrentContext("test-mc")
+	assert.EqualError(t, err, "context \"test-mc\" failed verification")
+
+	// --skip-verify bypasses the failing verifier.
+	require.NoError(t, SetCurrentContextSkipVerify("test-mc"))
+
+	// A passing verifier allows the switch and its evidence/timestamp are
+	// persisted on the context.
+	RegisterVerifier(v1alpha1.CtxTypeK8s, &fakeVerifier{ok: true, evidence: []byte("cert-chain")})
+	require.NoError(t, SetCurrentContext("test-mc"))
+
+	ctx, err := GetContext("test-mc")
+	require.NoError(t, err)
+	require.NotNil(t, ctx.LastVerifyResult)
+	assert.True(t, ctx.LastVerifyResult.OK)
+	assert.Equal(t, []byte("cert-chain"), ctx.LastVerifyResult.Evidence)
+	assert.False(t, ctx.LastVerifyResult.Timestamp.IsZero())
+
+	// A verifier error (as opposed to ok=false) is also surfaced and blocks
+	// the switch.
+	RegisterVerifier(v1alpha1.CtxTypeK8s, &fakeVerifier{err: fmt.Errorf("endpoint unreachable")})
+	err = SetCurrentContext("test-mc")
+	assert.EqualError(t, err, "could not verify context \"test-mc\": endpoint unreachable")
+
+	// Removing the context clears its cached verification evidence along
+	// with everything else about it.
+	RegisterVerifier(v1alpha1.CtxTypeK8s, &fakeVerifier{ok: true})
+	require.NoError(t, RemoveContext("test-mc"))
+	_, err = GetContext("test-mc")
+	assert.Error(t, err)
+}
+
+func TestGetCurrentContextStaleness(t *testing.T) {
+	setup(t)
+	defer cleanup()
+	defer delete(verifiers, v1alpha1.CtxTypeK8s)
+
+	staleAfter := VerificationStaleAfter
+	defer func() { VerificationStaleAfter = staleAfter }()
+
+	RegisterVerifier(v1alpha1.CtxTypeK8s, &fakeVerifier{ok: true})
+	require.NoError(t, SetCurrentContext("test-mc"))
+
+	// A recent passing verification is fresh.
+	ctx, err := GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "test-mc", ctx.Name)
+
+	// Once it's older than VerificationStaleAfter, the context is still
+	// returned but wrapped with ErrVerificationStale.
+	VerificationStaleAfter = 0
+	ctx, err = GetCurrentContext(v1alpha1.CtxTypeK8s)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrVerificationStale))
+	require.NotNil(t, ctx)
+	assert.Equal(t, "test-mc", ctx.Name)
+
+	// A context type without a registered verifier is never flagged stale.
+	ctx, err = GetCurrentContext(v1alpha1.CtxTypeTMC)
+	require.NoError(t, err)
+	assert.Equal(t, "test-tmc", ctx.Name)
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// AddContextGroup creates a new, empty context group with the given name.
+func AddContextGroup(name string) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	if getContextGroup(cfg, name) != nil {
+		return fmt.Errorf("context group %q already exists", name)
+	}
+
+	cfg.ContextGroups = append(cfg.ContextGroups, &v1alpha1.ContextGroup{Name: name})
+
+	return StoreClientConfig(cfg)
+}
+
+// RemoveContextGroup removes the context group identified by name, along
+// with every context that belongs to it. If the group is the current
+// context group, the current context group is cleared.
+func RemoveContextGroup(name string) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	idx := -1
+	for i, group := range cfg.ContextGroups {
+		if group.Name == name {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("context group %q not found", name)
+	}
+	cfg.ContextGroups = append(cfg.ContextGroups[:idx], cfg.ContextGroups[idx+1:]...)
+
+	remaining := cfg.KnownContexts[:0]
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.ContextGroup == name {
+			clearCurrentContextReferences(cfg, ctx)
+			continue
+		}
+		remaining = append(remaining, ctx)
+	}
+	cfg.KnownContexts = remaining
+
+	if cfg.CurrentContextGroup == name {
+		cfg.CurrentContextGroup = ""
+	}
+
+	return StoreClientConfig(cfg)
+}
+
+// GetContextGroup retrieves the context group identified by name.
+func GetContextGroup(name string) (*v1alpha1.ContextGroup, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	group := getContextGroup(cfg, name)
+	if group == nil {
+		return nil, fmt.Errorf("context group %q not found", name)
+	}
+
+	return group, nil
+}
+
+// SetCurrentContextGroup sets the context group identified by name as the
+// active group, so that SetCurrentContext/GetCurrentContext resolve within
+// it first.
+func SetCurrentContextGroup(name string) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	if getContextGroup(cfg, name) == nil {
+		return fmt.Errorf("context group %q not found", name)
+	}
+	cfg.CurrentContextGroup = name
+
+	return StoreClientConfig(cfg)
+}
+
+// ListContextsInGroup returns every context that belongs to the named group.
+func ListContextsInGroup(name string) ([]*v1alpha1.Context, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if getContextGroup(cfg, name) == nil {
+		return nil, fmt.Errorf("context group %q not found", name)
+	}
+
+	var contexts []*v1alpha1.Context
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.ContextGroup == name {
+			contexts = append(contexts, ctx)
+		}
+	}
+
+	return contexts, nil
+}
+
+// getContextGroup finds the context group with the given name, or nil if
+// name is empty or no such group exists.
+func getContextGroup(cfg *v1alpha1.ClientConfig, name string) *v1alpha1.ContextGroup {
+	if name == "" {
+		return nil
+	}
+	for _, group := range cfg.ContextGroups {
+		if group.Name == name {
+			return group
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// ContextHistoryLimit is the maximum number of prior current contexts kept
+// per ContextType. It is a var (rather than a const) so callers may tune it;
+// it defaults to 20.
+var ContextHistoryLimit = 20
+
+// PushContext sets name as the current context for its type, same as
+// SetCurrentContext, recording the previous current context in the history
+// so it can later be restored with PopContext.
+func PushContext(name string) error {
+	return SetCurrentContext(name)
+}
+
+// PopContext restores the most recently pushed current context for ctxType,
+// atomically under the config lock. If a context group is active, its own
+// history/current context are used, matching PushContext/SetCurrentContext;
+// otherwise the global history/current context are used. It returns an
+// error if the history for ctxType is empty.
+func PopContext(ctxType v1alpha1.ContextType) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return err
+	}
+
+	group := getContextGroup(cfg, cfg.CurrentContextGroup)
+	history := historyMapFor(cfg, group)
+
+	entries := (*history)[ctxType]
+	if len(entries) == 0 {
+		return fmt.Errorf("no previous context to pop for type %q", ctxType)
+	}
+
+	previous := entries[len(entries)-1]
+	(*history)[ctxType] = entries[:len(entries)-1]
+
+	if group != nil {
+		if group.CurrentContext == nil {
+			group.CurrentContext = make(map[v1alpha1.ContextType]string)
+		}
+		group.CurrentContext[ctxType] = previous
+	} else {
+		if cfg.CurrentContext == nil {
+			cfg.CurrentContext = make(map[v1alpha1.ContextType]string)
+		}
+		cfg.CurrentContext[ctxType] = previous
+	}
+	if ctxType == v1alpha1.CtxTypeK8s {
+		cfg.CurrentServer = previous
+	}
+
+	return StoreClientConfig(cfg)
+}
+
+// PeekPreviousContext returns the name of the context that PopContext would
+// restore for ctxType, without popping it. Like PopContext, it resolves the
+// active context group's own history first.
+func PeekPreviousContext(ctxType v1alpha1.ContextType) (string, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return "", err
+	}
+
+	group := getContextGroup(cfg, cfg.CurrentContextGroup)
+	entries := (*historyMapFor(cfg, group))[ctxType]
+	if len(entries) == 0 {
+		return "", fmt.Errorf("no previous context for type %q", ctxType)
+	}
+
+	return entries[len(entries)-1], nil
+}
+
+// historyMapFor returns a pointer to the ContextHistory map that backs
+// push/pop/prune for the given scope: group's own history if group is
+// non-nil, otherwise the global cfg.ContextHistory. Keeping each group's
+// history separate means same-named contexts in different groups don't
+// collide in the ring buffer or in pruning.
+func historyMapFor(cfg *v1alpha1.ClientConfig, group *v1alpha1.ContextGroup) *map[v1alpha1.ContextType][]string {
+	if group != nil {
+		return &group.ContextHistory
+	}
+	return &cfg.ContextHistory
+}
+
+// pushContextHistory appends name to the ring buffer of prior current
+// contexts for ctxType in history, evicting the oldest entry once
+// ContextHistoryLimit is exceeded. Callers must hold the config lock.
+func pushContextHistory(history *map[v1alpha1.ContextType][]string, ctxType v1alpha1.ContextType, name string) {
+	if *history == nil {
+		*history = make(map[v1alpha1.ContextType][]string)
+	}
+
+	entries := append((*history)[ctxType], name)
+	if len(entries) > ContextHistoryLimit {
+		entries = entries[len(entries)-ContextHistoryLimit:]
+	}
+	(*history)[ctxType] = entries
+}
+
+// pruneContextHistoryEntry removes every occurrence of name from the
+// ctxType bucket of history, used when the context it refers to is removed.
+// Callers must hold the config lock.
+func pruneContextHistoryEntry(history *map[v1alpha1.ContextType][]string, ctxType v1alpha1.ContextType, name string) {
+	entries := (*history)[ctxType]
+	if len(entries) == 0 {
+		return
+	}
+
+	pruned := entries[:0]
+	for _, entry := range entries {
+		if entry != name {
+			pruned = append(pruned, entry)
+		}
+	}
+	(*history)[ctxType] = pruned
+}
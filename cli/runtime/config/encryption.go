@@ -0,0 +1,152 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// encryptTag marks struct fields whose string value should be sealed at
+// rest, e.g. `encrypt:"true"`.
+const encryptTag = "encrypt"
+
+// encryptClientConfig seals every `encrypt:"true"` field of cfg's contexts
+// and servers in place, using key.
+func encryptClientConfig(cfg *v1alpha1.ClientConfig, key []byte) error {
+	return transformClientConfig(cfg, func(s string) (string, error) {
+		return encryptString(key, s)
+	})
+}
+
+// decryptClientConfig reverses encryptClientConfig.
+func decryptClientConfig(cfg *v1alpha1.ClientConfig, key []byte) error {
+	return transformClientConfig(cfg, func(s string) (string, error) {
+		return decryptString(key, s)
+	})
+}
+
+// transformClientConfig applies fn to every `encrypt:"true"` string field
+// reachable from cfg's contexts and servers, in place. Name and Type fields
+// are never tagged and so are left untouched, so enumeration and existence
+// checks keep working even when fn cannot be applied (e.g. no key).
+//
+// The one exception is a bootstrap context's Name: AddBootstrapContext uses
+// the raw "<id>.<secret>" token as the name, so the secret half would
+// otherwise be written to disk in the clear even though BootstrapOpts.Secret
+// itself is sealed. Name is transformed right along with it whenever
+// BootstrapOpts is set.
+func transformClientConfig(cfg *v1alpha1.ClientConfig, fn func(string) (string, error)) error {
+	for _, ctx := range cfg.KnownContexts {
+		if err := transformTaggedFields(ctx.ClusterOpts, fn); err != nil {
+			return err
+		}
+		if err := transformTaggedFields(ctx.GlobalOpts, fn); err != nil {
+			return err
+		}
+		if err := transformTaggedFields(ctx.BootstrapOpts, fn); err != nil {
+			return err
+		}
+		if ctx.BootstrapOpts != nil && ctx.Name != "" {
+			name, err := fn(ctx.Name)
+			if err != nil {
+				return err
+			}
+			ctx.Name = name
+		}
+	}
+	for _, srv := range cfg.KnownServers {
+		if err := transformTaggedFields(srv.ClusterOpts, fn); err != nil {
+			return err
+		}
+		if err := transformTaggedFields(srv.GlobalOpts, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transformTaggedFields walks the exported string fields of v (a pointer to
+// a struct, possibly nil) tagged `encrypt:"true"` and replaces each
+// non-empty value with fn(value).
+func transformTaggedFields(v interface{}, fn func(string) (string, error)) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil
+	}
+
+	elem := rv.Elem()
+	t := elem.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get(encryptTag) != "true" {
+			continue
+		}
+		fv := elem.Field(i)
+		if fv.Kind() != reflect.String || fv.String() == "" {
+			continue
+		}
+		out, err := fn(fv.String())
+		if err != nil {
+			return err
+		}
+		fv.SetString(out)
+	}
+	return nil
+}
+
+// encryptString seals plaintext with AES-GCM under key, returning a
+// base64-encoded nonce+ciphertext.
+func encryptString(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptString reverses encryptString.
+func decryptString(key []byte, encoded string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted field: %w", err)
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted field: ciphertext too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt field: %w", err)
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
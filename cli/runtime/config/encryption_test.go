@@ -0,0 +1,138 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// fakeKeyProvider is an in-memory KeyProvider used to test the encryption
+// layer without touching a real OS keyring.
+type fakeKeyProvider struct {
+	name string
+	key  []byte
+	err  error
+}
+
+func (f *fakeKeyProvider) Name() string { return f.name }
+
+func (f *fakeKeyProvider) GetKey() ([]byte, error) { return f.key, f.err }
+
+func TestEncryptedContextRoundTrip(t *testing.T) {
+	provider := &fakeKeyProvider{name: "fake-test", key: make([]byte, 32)}
+	RegisterKeyProvider(provider.name, provider)
+	t.Setenv(TanzuConfigEncryptionEnvVar, provider.name)
+
+	// Encryption must be active from the very first write, since sealed
+	// and plaintext fields cannot be mixed in the same config file.
+	setup(t)
+	defer cleanup()
+
+	ctx := &v1alpha1.Context{
+		Name: "test-mc-encrypted",
+		Type: v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{
+			Endpoint: "https://secret-endpoint.example.com",
+			Path:     "secret-kubeconfig-path",
+		},
+	}
+	require.NoError(t, AddContext(ctx, false))
+
+	// Enumeration/existence checks don't need the key since Name/Type are
+	// never sealed.
+	ok, err := ContextExists(ctx.Name)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// The round trip through the encrypted config transparently decrypts
+	// the sealed fields back to their original values.
+	got, err := GetContext(ctx.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "https://secret-endpoint.example.com", got.ClusterOpts.Endpoint)
+	assert.Equal(t, "secret-kubeconfig-path", got.ClusterOpts.Path)
+
+	// On disk, the sealed fields are not stored in the clear.
+	path, err := configFilePath()
+	require.NoError(t, err)
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "secret-endpoint")
+	assert.Contains(t, string(raw), ctx.Name)
+}
+
+func TestEncryptedBootstrapContextName(t *testing.T) {
+	provider := &fakeKeyProvider{name: "fake-test-bootstrap", key: make([]byte, 32)}
+	RegisterKeyProvider(provider.name, provider)
+	t.Setenv(TanzuConfigEncryptionEnvVar, provider.name)
+
+	setup(t)
+	defer cleanup()
+
+	token := "abc123.0123456789abcdef"
+	require.NoError(t, AddBootstrapContext(&v1alpha1.Context{
+		Name: token,
+		Type: v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{
+			Endpoint: "test-endpoint",
+		},
+	}, time.Hour))
+
+	// The round trip through the encrypted config transparently decrypts
+	// the name back to the original token, same as the sealed fields.
+	got, err := GetContext(token)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", got.BootstrapOpts.Secret)
+
+	// Unlike a regular context's Name, a bootstrap context's Name carries
+	// the token secret, so it must not be written to disk in the clear.
+	path, err := configFilePath()
+	require.NoError(t, err)
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), token)
+	assert.NotContains(t, string(raw), "0123456789abcdef")
+}
+
+func TestEncryptedConfigKeyUnavailable(t *testing.T) {
+	setup(t)
+	defer cleanup()
+
+	provider := &fakeKeyProvider{name: "fake-test-locked", err: errors.New("keyring is locked")}
+	RegisterKeyProvider(provider.name, provider)
+	t.Setenv(TanzuConfigEncryptionEnvVar, provider.name)
+
+	err := AddContext(&v1alpha1.Context{
+		Name: "test-mc-locked",
+		Type: v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{
+			Endpoint: "test-endpoint",
+		},
+	}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not encrypt config")
+}
+
+func TestUnregisteredKeyProvider(t *testing.T) {
+	setup(t)
+	defer cleanup()
+
+	t.Setenv(TanzuConfigEncryptionEnvVar, "does-not-exist")
+
+	err := AddContext(&v1alpha1.Context{
+		Name: "test-mc-unregistered",
+		Type: v1alpha1.CtxTypeK8s,
+		ClusterOpts: &v1alpha1.ClusterServer{
+			Endpoint: "test-endpoint",
+		},
+	}, false)
+	assert.EqualError(t, err, "no key provider registered for \"does-not-exist\"")
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	envFileProviderName       = "env"
+	configEncryptionKeyEnvVar = "TANZU_CONFIG_ENCRYPTION_KEY"
+	configKeyFileName         = "config.key"
+)
+
+// envFileKeyProvider resolves the config encryption key from
+// TANZU_CONFIG_ENCRYPTION_KEY if set, otherwise from a key file alongside
+// the config file, generating one on first use. It is the fallback for
+// environments without an OS keyring, e.g. headless CI.
+type envFileKeyProvider struct{}
+
+func (envFileKeyProvider) Name() string { return envFileProviderName }
+
+func (envFileKeyProvider) GetKey() ([]byte, error) {
+	if encoded := os.Getenv(configEncryptionKeyEnvVar); encoded != "" {
+		return base64.StdEncoding.DecodeString(encoded)
+	}
+
+	dir, err := localDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, configKeyFileName)
+
+	b, err := os.ReadFile(path)
+	if err == nil {
+		return base64.StdEncoding.DecodeString(string(b))
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)), 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func init() {
+	RegisterKeyProvider(envFileProviderName, envFileKeyProvider{})
+}
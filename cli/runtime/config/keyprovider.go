@@ -0,0 +1,60 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// KeyProvider supplies the symmetric key used to encrypt sensitive context
+// fields (endpoints, paths, auth material) at rest.
+type KeyProvider interface {
+	// Name identifies the provider. TANZU_CONFIG_ENCRYPTION is matched
+	// against the name passed to RegisterKeyProvider, not against Name;
+	// the built-in providers register under their Name.
+	Name() string
+
+	// GetKey returns the encryption key, fetching or generating it as
+	// needed.
+	GetKey() ([]byte, error)
+}
+
+// TanzuConfigEncryptionEnvVar selects which registered KeyProvider encrypts
+// the config at rest. If unset or set to "none", contexts are stored in
+// plaintext.
+const TanzuConfigEncryptionEnvVar = "TANZU_CONFIG_ENCRYPTION"
+
+var (
+	keyProvidersMu sync.Mutex
+	keyProviders   = map[string]KeyProvider{}
+)
+
+// RegisterKeyProvider registers a KeyProvider under name so that it can be
+// selected via TANZU_CONFIG_ENCRYPTION. Registering under an existing name
+// replaces the previous provider.
+func RegisterKeyProvider(name string, provider KeyProvider) {
+	keyProvidersMu.Lock()
+	defer keyProvidersMu.Unlock()
+	keyProviders[name] = provider
+}
+
+// activeKeyProvider returns the KeyProvider selected by
+// TANZU_CONFIG_ENCRYPTION, or nil if encryption is disabled.
+func activeKeyProvider() (KeyProvider, error) {
+	name := os.Getenv(TanzuConfigEncryptionEnvVar)
+	if name == "" || name == "none" {
+		return nil, nil
+	}
+
+	keyProvidersMu.Lock()
+	defer keyProvidersMu.Unlock()
+
+	provider, ok := keyProviders[name]
+	if !ok {
+		return nil, fmt.Errorf("no key provider registered for %q", name)
+	}
+	return provider, nil
+}
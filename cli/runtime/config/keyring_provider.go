@@ -0,0 +1,16 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+// keyringProviderName is the TANZU_CONFIG_ENCRYPTION selector for the
+// platform keyring provider (macOS Keychain, Linux Secret Service). Its
+// implementation lives in the OS-specific keyring_provider_<os>.go files:
+// macOS and Linux shell out to their own native credential-store
+// command-line tool instead of pulling in a third-party keyring dependency,
+// and on Windows the provider's GetKey returns an error.
+const (
+	keyringProviderName = "keyring"
+	keyringService      = "tanzu-cli-config"
+	keyringUser         = "config-encryption-key"
+)
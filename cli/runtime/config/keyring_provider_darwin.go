@@ -0,0 +1,47 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// keyringKeyProvider seals the config encryption key in the macOS Keychain
+// via the `security` command-line tool.
+type keyringKeyProvider struct{}
+
+func (keyringKeyProvider) Name() string { return keyringProviderName }
+
+func (keyringKeyProvider) GetKey() ([]byte, error) {
+	out, err := exec.Command("security", "find-generic-password", "-a", keyringUser, "-s", keyringService, "-w").Output() //nolint:gosec
+	if err == nil {
+		return base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+	}
+	if _, ok := err.(*exec.ExitError); !ok {
+		return nil, fmt.Errorf("could not query the macOS Keychain: %w", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(key)
+
+	cmd := exec.Command("security", "add-generic-password", "-a", keyringUser, "-s", keyringService, "-w", encoded, "-U") //nolint:gosec
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("could not store key in the macOS Keychain: %w (%s)", err, bytes.TrimSpace(out))
+	}
+
+	return key, nil
+}
+
+func init() {
+	RegisterKeyProvider(keyringProviderName, keyringKeyProvider{})
+}
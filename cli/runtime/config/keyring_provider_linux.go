@@ -0,0 +1,46 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// keyringKeyProvider seals the config encryption key in the freedesktop
+// Secret Service keyring via the `secret-tool` command-line tool.
+type keyringKeyProvider struct{}
+
+func (keyringKeyProvider) Name() string { return keyringProviderName }
+
+func (keyringKeyProvider) GetKey() ([]byte, error) {
+	out, err := exec.Command("secret-tool", "lookup", "service", keyringService, "account", keyringUser).Output() //nolint:gosec
+	if err == nil && len(strings.TrimSpace(string(out))) > 0 {
+		return base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(key)
+
+	cmd := exec.Command("secret-tool", "store", "--label=tanzu CLI config encryption key", //nolint:gosec
+		"service", keyringService, "account", keyringUser)
+	cmd.Stdin = bytes.NewBufferString(encoded)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("could not store key in the Secret Service keyring: %w (%s)", err, bytes.TrimSpace(out))
+	}
+
+	return key, nil
+}
+
+func init() {
+	RegisterKeyProvider(keyringProviderName, keyringKeyProvider{})
+}
@@ -0,0 +1,23 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "errors"
+
+// keyringKeyProvider would seal the config encryption key in the Windows
+// Credential Manager, but reading back a stored credential's secret
+// requires Win32 APIs unreachable from the standard library alone. Rather
+// than pull in a third-party dependency for it, the "keyring" provider is
+// unavailable on Windows for now; use TANZU_CONFIG_ENCRYPTION=env instead.
+type keyringKeyProvider struct{}
+
+func (keyringKeyProvider) Name() string { return keyringProviderName }
+
+func (keyringKeyProvider) GetKey() ([]byte, error) {
+	return nil, errors.New(`the "keyring" provider is not supported on Windows; use TANZU_CONFIG_ENCRYPTION=env instead`)
+}
+
+func init() {
+	RegisterKeyProvider(keyringProviderName, keyringKeyProvider{})
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"sync"
+
+	"github.com/gofrs/flock"
+)
+
+// mutex guards concurrent access to the lock file handle from within this
+// process; the flock itself guards access across processes.
+var mutex sync.Mutex
+var fileLock *flock.Flock
+
+// AcquireTanzuConfigLock acquires a lock on the tanzu config file so that
+// concurrent readers/writers (including other processes) do not corrupt it.
+// Callers must call ReleaseTanzuConfigLock once they are done.
+func AcquireTanzuConfigLock() {
+	mutex.Lock()
+
+	path, err := configLockFilePath()
+	if err != nil {
+		// There is no home directory to lock a file in; nothing more we can
+		// do to serialize access, so proceed without a cross-process lock.
+		return
+	}
+
+	if fileLock == nil {
+		fileLock = flock.New(path)
+	}
+
+	if err := fileLock.Lock(); err != nil {
+		_ = os.Remove(path)
+	}
+}
+
+// ReleaseTanzuConfigLock releases the lock acquired by AcquireTanzuConfigLock.
+func ReleaseTanzuConfigLock() {
+	if fileLock != nil {
+		_ = fileLock.Unlock()
+	}
+	mutex.Unlock()
+}
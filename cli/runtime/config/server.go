@@ -0,0 +1,50 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// GetServer retrieves the deprecated server entry identified by name. The
+// entry is synthesized from the matching context, so every context (however
+// it was added) has a mirror, regardless of whether it came in through
+// AddContext.
+func GetServer(name string) (*v1alpha1.Server, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.Name == name {
+			return contextToServer(ctx), nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find server %q", name)
+}
+
+// ServerExists reports whether a deprecated server entry with the given name
+// is known. This stays in sync with ContextExists, since it is derived from
+// the same KnownContexts.
+func ServerExists(name string) (bool, error) {
+	_, err := GetServer(name)
+	if err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// GetCurrentServer retrieves the server mirroring the current k8s context.
+func GetCurrentServer() (*v1alpha1.Server, error) {
+	ctx, err := GetCurrentContext(v1alpha1.CtxTypeK8s)
+	if err != nil {
+		return nil, fmt.Errorf("no current server set")
+	}
+
+	return contextToServer(ctx), nil
+}
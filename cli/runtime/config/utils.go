@@ -0,0 +1,72 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// LocalDirName is the name of the local directory where tanzu config is
+// stored, relative to the user's home directory. It is a var (rather than a
+// const) so that tests can point it at a throwaway directory.
+var LocalDirName = ".config/tanzu"
+
+// configFileName is the name of the config file within LocalDirName.
+const configFileName = "config.yaml"
+
+// configLockFileName is the name of the lock file used to guard concurrent
+// access to the config file.
+const configLockFileName = "config.lock"
+
+// localDir returns the local tanzu config directory, creating it if it does
+// not already exist.
+func localDir() (path string, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	path = filepath.Join(home, LocalDirName)
+	_, err = os.Stat(path)
+	if os.IsNotExist(err) {
+		err = os.MkdirAll(path, 0755)
+		if err != nil {
+			return "", err
+		}
+	} else if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// configFilePath returns the full path to the config file.
+func configFilePath() (path string, err error) {
+	dir, err := localDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configFileName), nil
+}
+
+// configLockFilePath returns the full path to the config lock file.
+func configLockFilePath() (path string, err error) {
+	dir, err := localDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configLockFileName), nil
+}
+
+// cleanupDir removes the named local directory under the user's home
+// directory. It is used by tests to tear down the throwaway config dir
+// created via LocalDirName.
+func cleanupDir(dirName string) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	_ = os.RemoveAll(filepath.Join(home, dirName))
+}
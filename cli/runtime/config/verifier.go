@@ -0,0 +1,81 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// ContextVerifier checks that a context is healthy/trustworthy before it is
+// allowed to become the current context for its type.
+type ContextVerifier interface {
+	// Verify reports whether ctx is healthy, along with supporting evidence
+	// (e.g. a certificate chain, an introspection response) for audit.
+	Verify(ctx *v1alpha1.Context) (ok bool, evidence []byte, err error)
+}
+
+// ErrVerificationStale is wrapped into the error GetCurrentContext returns
+// when a ContextVerifier is registered for the context's type but its
+// LastVerifyResult is missing, failed, or older than VerificationStaleAfter.
+var ErrVerificationStale = errors.New("context verification is stale")
+
+// VerificationStaleAfter bounds how long a context's LastVerifyResult is
+// considered fresh by GetCurrentContext. It has no effect on contexts whose
+// type has no registered ContextVerifier.
+var VerificationStaleAfter = 24 * time.Hour
+
+var (
+	verifiersMu sync.Mutex
+	verifiers   = map[v1alpha1.ContextType]ContextVerifier{}
+)
+
+// RegisterVerifier registers verifier as the ContextVerifier consulted by
+// SetCurrentContext for every context of type ctxType. Registering under an
+// existing type replaces the previous verifier.
+func RegisterVerifier(ctxType v1alpha1.ContextType, verifier ContextVerifier) {
+	verifiersMu.Lock()
+	defer verifiersMu.Unlock()
+	verifiers[ctxType] = verifier
+}
+
+// verifierFor returns the ContextVerifier registered for ctxType, or nil.
+func verifierFor(ctxType v1alpha1.ContextType) ContextVerifier {
+	verifiersMu.Lock()
+	defer verifiersMu.Unlock()
+	return verifiers[ctxType]
+}
+
+// verifyContext runs the registered verifier (if any) for ctx.Type and, on
+// success, stamps ctx.LastVerifyResult. skipVerify bypasses the check
+// entirely, mirroring a CLI --skip-verify flag.
+func verifyContext(ctx *v1alpha1.Context, skipVerify bool) error {
+	if skipVerify {
+		return nil
+	}
+
+	verifier := verifierFor(ctx.Type)
+	if verifier == nil {
+		return nil
+	}
+
+	ok, evidence, err := verifier.Verify(ctx)
+	if err != nil {
+		return fmt.Errorf("could not verify context %q: %w", ctx.Name, err)
+	}
+	if !ok {
+		return fmt.Errorf("context %q failed verification", ctx.Name)
+	}
+
+	ctx.LastVerifyResult = &v1alpha1.VerificationResult{
+		OK:        true,
+		Evidence:  evidence,
+		Timestamp: time.Now(),
+	}
+	return nil
+}
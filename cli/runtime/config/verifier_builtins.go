@@ -0,0 +1,102 @@
+// Copyright 2022 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vmware-tanzu/tanzu-framework/apis/config/v1alpha1"
+)
+
+// tlsReachabilityVerifier is the built-in ContextVerifier for CtxTypeK8s: it
+// dials ClusterOpts.Endpoint and checks that the server's certificate chain
+// and SAN are valid for the host. It is not registered automatically; wire
+// it up with RegisterVerifier(v1alpha1.CtxTypeK8s, NewTLSReachabilityVerifier()).
+type tlsReachabilityVerifier struct {
+	dialTimeout time.Duration
+}
+
+// NewTLSReachabilityVerifier returns the built-in verifier for Kubernetes
+// contexts.
+func NewTLSReachabilityVerifier() ContextVerifier {
+	return &tlsReachabilityVerifier{dialTimeout: 5 * time.Second}
+}
+
+func (v *tlsReachabilityVerifier) Verify(ctx *v1alpha1.Context) (bool, []byte, error) {
+	if ctx.ClusterOpts == nil || ctx.ClusterOpts.Endpoint == "" {
+		return false, nil, fmt.Errorf("context %q has no cluster endpoint to verify", ctx.Name)
+	}
+
+	host := ctx.ClusterOpts.Endpoint
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "443")
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: v.dialTimeout}, "tcp", host, &tls.Config{}) //nolint:gosec
+	if err != nil {
+		return false, nil, fmt.Errorf("could not reach %q: %w", host, err)
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return false, nil, fmt.Errorf("no certificate presented by %q", host)
+	}
+
+	leaf := state.PeerCertificates[0]
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		hostname = host
+	}
+	if err := leaf.VerifyHostname(hostname); err != nil {
+		return false, leaf.Raw, fmt.Errorf("certificate for %q is not valid for %q: %w", host, hostname, err)
+	}
+
+	return true, leaf.Raw, nil
+}
+
+// tokenIntrospectionVerifier is the built-in ContextVerifier for CtxTypeTMC:
+// it sends a GET to the "/introspect" path of GlobalOpts.Endpoint and passes
+// if the response status is 200 OK, returning the response body as
+// evidence. No access token is sent with the request. It is not registered
+// automatically; wire it up with
+// RegisterVerifier(v1alpha1.CtxTypeTMC, NewTokenIntrospectionVerifier()).
+type tokenIntrospectionVerifier struct {
+	httpClient *http.Client
+}
+
+// NewTokenIntrospectionVerifier returns the built-in verifier for TMC
+// contexts.
+func NewTokenIntrospectionVerifier() ContextVerifier {
+	return &tokenIntrospectionVerifier{httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (v *tokenIntrospectionVerifier) Verify(ctx *v1alpha1.Context) (bool, []byte, error) {
+	if ctx.GlobalOpts == nil || ctx.GlobalOpts.Endpoint == "" {
+		return false, nil, fmt.Errorf("context %q has no global endpoint to verify", ctx.Name)
+	}
+
+	resp, err := v.httpClient.Get(strings.TrimRight(ctx.GlobalOpts.Endpoint, "/") + "/introspect")
+	if err != nil {
+		return false, nil, fmt.Errorf("could not introspect token for %q: %w", ctx.Name, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return false, body, fmt.Errorf("token introspection for %q returned status %d", ctx.Name, resp.StatusCode)
+	}
+
+	return true, body, nil
+}